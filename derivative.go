@@ -0,0 +1,33 @@
+package savitzkygolay
+
+import "fmt"
+
+// ProcessDerivative returns the order-th derivative estimate of data, using
+// the same window and polynomial degree as this filter but overriding the
+// derivative it was constructed with. It recomputes the weight matrix for
+// order (or, on the non-uniform path, lets processUnequal fit it per
+// window as usual) rather than requiring a second call to NewFilter for
+// every derivative order a caller wants.
+func (options filterConfiguration) ProcessDerivative(data []float64, x []float64, order int) ([]float64, error) {
+	if order < 0 {
+		return nil, fmt.Errorf("order [%d] must be equal to or greater than 0", order)
+	}
+	derived := options
+	derived.derivative = order
+	if !derived.unequal {
+		derived.weights = derived.computeWeights()
+	}
+	return derived.Process(data, x)
+}
+
+// ProcessDerivative returns the order-th derivative estimate produced by
+// the same IRLS outlier-rejection loop as Process, just read off the final
+// robust weights at a different derivative order.
+func (rf robustFilter) ProcessDerivative(data []float64, x []float64, order int) ([]float64, error) {
+	if order < 0 {
+		return nil, fmt.Errorf("order [%d] must be equal to or greater than 0", order)
+	}
+	derived := rf
+	derived.derivative = order
+	return derived.Process(data, x)
+}
@@ -0,0 +1,142 @@
+package savitzkygolay
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func l2Error(got, want []float64) float64 {
+	sum := 0.0
+	for i := range got {
+		d := got[i] - want[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(got)))
+}
+
+func Test_ProcessDerivative_MatchesAnalyticSin(t *testing.T) {
+	const n = 2000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	firstDerivative := make([]float64, n)
+	secondDerivative := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i) * 0.01
+		y[i] = math.Sin(x[i])
+		firstDerivative[i] = math.Cos(x[i])
+		secondDerivative[i] = -math.Sin(x[i])
+	}
+
+	filter, err := NewFilter(11, 0, 4)
+	assert.NoError(t, err, "No filter initialization error expected")
+
+	got1, err := filter.ProcessDerivative(y, x, 1)
+	assert.NoError(t, err, "No error expected")
+	assert.Less(t, l2Error(got1, firstDerivative), 0.05, "First derivative of sin should match cos")
+
+	got2, err := filter.ProcessDerivative(y, x, 2)
+	assert.NoError(t, err, "No error expected")
+	assert.Less(t, l2Error(got2, secondDerivative), 0.5, "Second derivative of sin should match -sin")
+}
+
+func Test_ProcessDerivative_ExactOnPolynomial(t *testing.T) {
+	const n = 200
+	x := make([]float64, n)
+	y := make([]float64, n)
+	firstDerivative := make([]float64, n)
+	for i := range x {
+		xi := float64(i)
+		x[i] = xi
+		y[i] = 2*xi*xi*xi - xi*xi + 3*xi - 5
+		firstDerivative[i] = 6*xi*xi - 2*xi + 3
+	}
+
+	filter, err := NewFilter(11, 0, 3)
+	assert.NoError(t, err, "No filter initialization error expected")
+	got, err := filter.ProcessDerivative(y, x, 1)
+	assert.NoError(t, err, "No error expected")
+
+	for i := range got {
+		assert.InDelta(t, firstDerivative[i], got[i], 1e-6, "A degree-matched fit should recover a polynomial's derivative exactly at index %d", i)
+	}
+}
+
+func Test_ProcessDerivative_NoisyGaussian(t *testing.T) {
+	const n = 2000
+	x := make([]float64, n)
+	clean := make([]float64, n)
+	firstDerivative := make([]float64, n)
+	for i := range x {
+		xi := float64(i)*0.02 - 20
+		x[i] = xi
+		clean[i] = math.Exp(-xi * xi / 8)
+		firstDerivative[i] = clean[i] * (-xi / 4)
+	}
+	noisy := make([]float64, n)
+	for i, v := range clean {
+		noisy[i] = v + noise(0.02)
+	}
+
+	filter, err := NewFilter(21, 0, 4)
+	assert.NoError(t, err, "No filter initialization error expected")
+	got, err := filter.ProcessDerivative(noisy, x, 1)
+	assert.NoError(t, err, "No error expected")
+	assert.Less(t, l2Error(got, firstDerivative), 0.1, "Derivative of a noisy Gaussian should stay close to the clean analytic derivative")
+}
+
+// makeBenchSeries returns n samples of a noisy sine wave at unit x
+// spacing, for use by the throughput benchmarks below.
+func makeBenchSeries(n int) (y, x []float64) {
+	y = make([]float64, n)
+	x = make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+		y[i] = 20*math.Sin(float64(i)/math.Pi/6) + noise(5.0)
+	}
+	return y, x
+}
+
+// BenchmarkProcess measures Process throughput across a range of window
+// sizes; run with -bench and compare with benchstat to catch regressions.
+func BenchmarkProcess(b *testing.B) {
+	y, x := makeBenchSeries(5000)
+	for _, windowSize := range []int{5, 11, 21, 41, 81} {
+		filter, err := NewFilter(windowSize, 0, 3)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("window=%d", windowSize), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := filter.Process(y, x); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(y)*b.N)/b.Elapsed().Seconds(), "samples/sec")
+		})
+	}
+}
+
+// BenchmarkProcessDerivative measures ProcessDerivative throughput across
+// the same window sizes as BenchmarkProcess.
+func BenchmarkProcessDerivative(b *testing.B) {
+	y, x := makeBenchSeries(5000)
+	for _, windowSize := range []int{5, 11, 21, 41, 81} {
+		filter, err := NewFilter(windowSize, 0, 3)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("window=%d", windowSize), func(b *testing.B) {
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := filter.ProcessDerivative(y, x, 1); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(y)*b.N)/b.Elapsed().Seconds(), "samples/sec")
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package savitzkygolay
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Filter2D is the two-dimensional analogue of Filter: it smooths (or
+// differentiates) data laid out on a regular grid, such as an image or a
+// heightmap, by fitting a bivariate polynomial to each window.
+type Filter2D interface {
+	Process2D(data [][]float64) ([][]float64, error)
+}
+
+// filter2DConfiguration provides configuration for a 2D Savitzky-Golay
+// filter.
+type filter2DConfiguration struct {
+	windowX    int
+	windowY    int
+	derivX     int
+	derivY     int
+	polynomial int
+
+	// weights[ty+halfY][tx+halfX] is the windowY x windowX kernel used when
+	// the point being estimated sits at grid offset (tx, ty) from the
+	// center of its window, the 2D analogue of filterConfiguration.weights.
+	weights [][][][]float64
+}
+
+// NewFilter2D creates a 2D Savitzky-Golay filter. windowX and windowY must
+// each be odd and at least 5. polynomial is the total degree of the
+// bivariate polynomial fit (monomials x^a*y^b with a+b <= polynomial).
+// derivX and derivY select the partial derivative order along each axis;
+// (0, 0) smooths the grid, (1, 0) estimates d/dx, (0, 1) estimates d/dy,
+// and (1, 1) estimates the mixed partial, for example.
+func NewFilter2D(windowX int, windowY int, derivX int, derivY int, polynomial int) (Filter2D, error) {
+	if err := validateFilterOptions(windowX, derivX, polynomial); err != nil {
+		return nil, fmt.Errorf("windowX: %w", err)
+	}
+	if err := validateFilterOptions(windowY, derivY, polynomial); err != nil {
+		return nil, fmt.Errorf("windowY: %w", err)
+	}
+	options := filter2DConfiguration{windowX: windowX, windowY: windowY, derivX: derivX, derivY: derivY, polynomial: polynomial}
+	options.weights = options.computeWeights2D()
+	return options, nil
+}
+
+// Process2D applies the filter to data, a row-major grid where every row
+// has the same length, returning a grid of the same size. Near the edges
+// it uses the same asymmetric border kernel strategy as Filter.Process:
+// the window is clamped to stay inside the grid and the kernel for the
+// point's actual offset within that window is used instead of the
+// centered one.
+func (options filter2DConfiguration) Process2D(data [][]float64) ([][]float64, error) {
+	h := len(data)
+	if h == 0 {
+		return nil, fmt.Errorf("data must have at least one row")
+	}
+	w := len(data[0])
+	if h < options.windowY || w < options.windowX {
+		return nil, fmt.Errorf("data size [%dx%d] must be at least as large as the window [%dx%d]", h, w, options.windowY, options.windowX)
+	}
+	for r, row := range data {
+		if len(row) != w {
+			return nil, fmt.Errorf("data row %d has length [%d], expected every row to have length [%d]", r, len(row), w)
+		}
+	}
+
+	halfY := options.windowY / 2
+	halfX := options.windowX / 2
+	results := make([][]float64, h)
+	for r := range results {
+		results[r] = make([]float64, w)
+	}
+
+	for r := 0; r < h; r++ {
+		rowStart := clamp(r-halfY, 0, h-options.windowY)
+		ty := r - rowStart - halfY
+		for c := 0; c < w; c++ {
+			colStart := clamp(c-halfX, 0, w-options.windowX)
+			tx := c - colStart - halfX
+
+			kernel := options.weights[ty+halfY][tx+halfX]
+			sum := 0.0
+			for j := 0; j < options.windowY; j++ {
+				row := data[rowStart+j]
+				kernelRow := kernel[j]
+				for i := 0; i < options.windowX; i++ {
+					sum += kernelRow[i] * row[colStart+i]
+				}
+			}
+			results[r][c] = sum
+		}
+	}
+	return results, nil
+}
+
+// clamp restricts v to the closed interval [lo, hi].
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// computeWeights2D mirrors filterConfiguration.computeWeights, generalized
+// to two dimensions: it fits a bivariate polynomial of total degree
+// options.polynomial once via least squares, then differentiates and
+// evaluates that fit at every offset within the window to produce the
+// border kernels Process2D needs.
+func (options filter2DConfiguration) computeWeights2D() [][][][]float64 {
+	halfY := options.windowY / 2
+	halfX := options.windowX / 2
+
+	type monomial struct{ a, b int } // x^a * y^b
+	var monomials []monomial
+	for total := 0; total <= options.polynomial; total++ {
+		for a := 0; a <= total; a++ {
+			monomials = append(monomials, monomial{a: a, b: total - a})
+		}
+	}
+
+	n := options.windowY * options.windowX
+	a := mat.NewDense(n, len(monomials), nil)
+	for j := 0; j < options.windowY; j++ {
+		y := float64(j - halfY)
+		for i := 0; i < options.windowX; i++ {
+			x := float64(i - halfX)
+			row := j*options.windowX + i
+			for idx, m := range monomials {
+				a.Set(row, idx, intPow(x, m.a)*intPow(y, m.b))
+			}
+		}
+	}
+
+	var ata mat.Dense
+	ata.Mul(a.T(), a)
+	var ataInv mat.Dense
+	if err := ataInv.Inverse(&ata); err != nil {
+		panic(fmt.Sprintf("savitzkygolay: could not solve normal equations for 2D window %dx%d, polynomial %d: %v", options.windowX, options.windowY, options.polynomial, err))
+	}
+	var coefficients mat.Dense // len(monomials) x n
+	coefficients.Mul(&ataInv, a.T())
+
+	weights := make([][][][]float64, options.windowY)
+	for ty := -halfY; ty <= halfY; ty++ {
+		weights[ty+halfY] = make([][][]float64, options.windowX)
+		for tx := -halfX; tx <= halfX; tx++ {
+			kernel := make([][]float64, options.windowY)
+			for j := range kernel {
+				kernel[j] = make([]float64, options.windowX)
+			}
+			for idx, m := range monomials {
+				if m.a < options.derivX || m.b < options.derivY {
+					continue
+				}
+				scale := fallingFactorial(m.a, options.derivX) * fallingFactorial(m.b, options.derivY) *
+					intPow(float64(tx), m.a-options.derivX) * intPow(float64(ty), m.b-options.derivY)
+				for j := 0; j < options.windowY; j++ {
+					for i := 0; i < options.windowX; i++ {
+						kernel[j][i] += coefficients.At(idx, j*options.windowX+i) * scale
+					}
+				}
+			}
+			weights[ty+halfY][tx+halfX] = kernel
+		}
+	}
+	return weights
+}
@@ -0,0 +1,65 @@
+package savitzkygolay
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func makeGrid(h, w int, f func(r, c int) float64) [][]float64 {
+	grid := make([][]float64, h)
+	for r := range grid {
+		grid[r] = make([]float64, w)
+		for c := range grid[r] {
+			grid[r][c] = f(r, c)
+		}
+	}
+	return grid
+}
+
+func Test_NewFilter2D_Args(t *testing.T) {
+	_, err := NewFilter2D(6, 5, 0, 0, 2)
+	assert.Error(t, err, "windowX must be odd")
+	_, err = NewFilter2D(5, 6, 0, 0, 2)
+	assert.Error(t, err, "windowY must be odd")
+}
+
+func Test_Filter2D_ConstantPlane(t *testing.T) {
+	grid := makeGrid(20, 20, func(r, c int) float64 { return 3.5 })
+
+	filter, err := NewFilter2D(5, 5, 0, 0, 2)
+	assert.NoError(t, err, "No filter initialization error expected")
+	smoothed, err := filter.Process2D(grid)
+	assert.NoError(t, err, "No error expected")
+
+	for r := range smoothed {
+		for c := range smoothed[r] {
+			assert.InDelta(t, 3.5, smoothed[r][c], 1e-9, "Constant grid should stay constant at (%d,%d)", r, c)
+		}
+	}
+}
+
+func Test_Filter2D_RaggedRowsReturnError(t *testing.T) {
+	grid := makeGrid(10, 10, func(r, c int) float64 { return 0 })
+	grid[4] = grid[4][:5]
+
+	filter, err := NewFilter2D(5, 5, 0, 0, 2)
+	assert.NoError(t, err, "No filter initialization error expected")
+	_, err = filter.Process2D(grid)
+	assert.Error(t, err, "Ragged rows should be rejected instead of panicking")
+}
+
+func Test_Filter2D_LinearGradientX(t *testing.T) {
+	grid := makeGrid(15, 15, func(r, c int) float64 { return 2*float64(c) + 1 })
+
+	filter, err := NewFilter2D(5, 5, 1, 0, 2)
+	assert.NoError(t, err, "No filter initialization error expected")
+	dx, err := filter.Process2D(grid)
+	assert.NoError(t, err, "No error expected")
+
+	for r := range dx {
+		for c := range dx[r] {
+			assert.InDelta(t, 2.0, dx[r][c], 1e-6, "d/dx of a linear ramp should be its slope at (%d,%d)", r, c)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package savitzkygolay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// NewFilterUnequal creates a filter for data sampled at non-uniform
+// x-positions, such as a time series with jitter or missing samples.
+// Process still slides a windowSize-wide window across the data, but
+// instead of the precomputed uniform-spacing weights used by NewFilter, it
+// fits a local degree-`polynomial` polynomial to the actual x offsets in
+// each window and reads the smoothed value or derivative off that fit.
+// Process on a filter built by NewFilter also takes this path
+// automatically whenever the x values it is given turn out to be
+// non-uniform, so NewFilterUnequal is only needed to opt in to the
+// per-point fit (and its caching) up front.
+func NewFilterUnequal(windowSize int, derivative int, polynomial int) (Filter, error) {
+	if err := validateFilterOptions(windowSize, derivative, polynomial); err != nil {
+		return nil, err
+	}
+	return filterConfiguration{
+		windowSize: windowSize,
+		derivative: derivative,
+		polynomial: polynomial,
+		unequal:    true,
+		cache:      make(map[string][]float64),
+	}, nil
+}
+
+// isUniform reports whether the spacing between consecutive x values is
+// constant to within a small relative tolerance.
+func isUniform(x []float64) bool {
+	if len(x) < 3 {
+		return true
+	}
+	n := len(x) - 1
+	sum, sumSq := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		d := x[i+1] - x[i]
+		sum += d
+		sumSq += d * d
+	}
+	mean := sum / float64(n)
+	variance := sumSq/float64(n) - mean*mean
+
+	const tolerance = 1e-9
+	return variance <= tolerance*mean*mean
+}
+
+// processUnequal implements Process for non-uniformly spaced x values by
+// fitting a local polynomial around each point instead of reusing a single
+// precomputed weight matrix.
+func (options filterConfiguration) processUnequal(data []float64, x []float64) ([]float64, error) {
+	if len(x) != len(data) {
+		return nil, fmt.Errorf("x length [%d] must match data length [%d]", len(x), len(data))
+	}
+
+	halfWindow := options.windowSize / 2
+	numPoints := len(data)
+	results := make([]float64, numPoints)
+
+	for i := 0; i < numPoints; i++ {
+		start := i - halfWindow
+		if start < 0 {
+			start = 0
+		}
+		if start > numPoints-options.windowSize {
+			start = numPoints - options.windowSize
+		}
+		window := x[start : start+options.windowSize]
+		offsets := relativeOffsets(window, i-start)
+		key := strideKey(offsets, options.derivative)
+
+		weights, ok := options.cache[key]
+		if !ok {
+			var err error
+			weights, err = fitLocalWeights(offsets, options.polynomial, options.derivative)
+			if err != nil {
+				return nil, fmt.Errorf("could not fit local polynomial around x[%d]: %w", i, err)
+			}
+			options.cache[key] = weights
+		}
+
+		d := 0.0
+		for j, w := range weights {
+			d += w * data[start+j]
+		}
+		results[i] = d
+	}
+	return results, nil
+}
+
+// relativeOffsets returns the x offsets of window relative to the element
+// at index anchor.
+func relativeOffsets(window []float64, anchor int) []float64 {
+	offsets := make([]float64, len(window))
+	for j, xv := range window {
+		offsets[j] = xv - window[anchor]
+	}
+	return offsets
+}
+
+// strideKey turns a window's x offsets and the derivative order being
+// fitted into a string suitable for use as a cache key, so repeated stride
+// patterns reuse the same fitted weights.
+func strideKey(offsets []float64, derivative int) string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(derivative))
+	b.WriteByte(':')
+	for _, o := range offsets {
+		b.WriteString(strconv.FormatFloat(o, 'g', 9, 64))
+		b.WriteByte('|')
+	}
+	return b.String()
+}
+
+// fitLocalWeights solves the least-squares polynomial fit for a window
+// whose points sit at the given offsets from the point being estimated,
+// and returns the weight row that extracts the derivative-th derivative of
+// that fit evaluated at the point itself (offset 0).
+func fitLocalWeights(offsets []float64, polynomial int, derivative int) ([]float64, error) {
+	sampleWeights := make([]float64, len(offsets))
+	for i := range sampleWeights {
+		sampleWeights[i] = 1
+	}
+	return fitWeightedLocalWeights(offsets, sampleWeights, polynomial, derivative)
+}
+
+// fitWeightedLocalWeights is fitLocalWeights generalized to a weighted
+// least-squares fit, used by NewRobustFilter to down-weight outliers.
+func fitWeightedLocalWeights(offsets []float64, sampleWeights []float64, polynomial int, derivative int) ([]float64, error) {
+	order := polynomial + 1
+	n := len(offsets)
+
+	a := mat.NewDense(n, order, nil)
+	wa := mat.NewDense(n, order, nil)
+	for j, off := range offsets {
+		p := 1.0
+		for k := 0; k < order; k++ {
+			a.Set(j, k, p)
+			wa.Set(j, k, sampleWeights[j]*p)
+			p *= off
+		}
+	}
+
+	var ata mat.Dense
+	ata.Mul(a.T(), wa)
+	var ataInv mat.Dense
+	if err := ataInv.Inverse(&ata); err != nil {
+		return nil, err
+	}
+	var coefficients mat.Dense // order x n
+	coefficients.Mul(&ataInv, wa.T())
+
+	weights := make([]float64, n)
+	scale := fallingFactorial(derivative, derivative) // derivative!
+	for j := 0; j < n; j++ {
+		weights[j] = coefficients.At(derivative, j) * scale
+	}
+	return weights, nil
+}
@@ -0,0 +1,61 @@
+package savitzkygolay
+
+import (
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_NewFilterUnequal_Args(t *testing.T) {
+	_, err := NewFilterUnequal(6, 0, 3)
+	assert.Error(t, err, "Window size even")
+}
+
+func Test_FilterUnequal_ConstantLine(t *testing.T) {
+	xs := []float64{0, 1, 2, 4, 5, 7, 8, 10, 11, 13, 14}
+	ys := make([]float64, len(xs))
+	for i := range ys {
+		ys[i] = math.Pi
+	}
+
+	filter, err := NewFilterUnequal(5, 0, 3)
+	assert.NoError(t, err, "No filter initialization error expected")
+	smoothed, err := filter.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+	for i, v := range smoothed {
+		assert.InDelta(t, math.Pi, v, 1e-6, "Constant input should stay constant at index %d", i)
+	}
+}
+
+func Test_Process_AutoDetectsNonUniformSpacing(t *testing.T) {
+	xs := []float64{0, 1, 2, 4, 5, 7, 8, 10, 11, 13, 14}
+	ys := make([]float64, len(xs))
+	for i := range ys {
+		ys[i] = math.Pi
+	}
+
+	filter, err := NewFilter(5, 0, 3)
+	assert.NoError(t, err, "No filter initialization error expected")
+	smoothed, err := filter.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+	for i, v := range smoothed {
+		assert.InDelta(t, math.Pi, v, 1e-6, "Constant input should stay constant at index %d", i)
+	}
+}
+
+func Test_FilterUnequal_LinearSlope(t *testing.T) {
+	xs := []float64{0, 1, 2, 4, 5, 7, 8, 10, 11, 13, 14}
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = 3*x + 1
+	}
+
+	filter, err := NewFilterUnequal(5, 1, 3)
+	assert.NoError(t, err, "No filter initialization error expected")
+	derivative, err := filter.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+	for i, v := range derivative {
+		assert.InDelta(t, 3.0, v, 1e-6, "First derivative of a line should be its slope at index %d", i)
+	}
+}
@@ -0,0 +1,45 @@
+package savitzkygolay
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_NewRobustFilter_Args(t *testing.T) {
+	_, err := NewRobustFilter(6, 0, 3, RobustOptions{})
+	assert.Error(t, err, "Window size even")
+}
+
+func Test_RobustFilter_RejectsSpike(t *testing.T) {
+	const n = 41
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i)
+		ys[i] = 10.0
+	}
+	ys[n/2] = 1000.0 // a single wild outlier
+
+	filter, err := NewRobustFilter(9, 0, 2, RobustOptions{})
+	assert.NoError(t, err, "No filter initialization error expected")
+	smoothed, err := filter.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+
+	assert.Less(t, smoothed[n/2], 100.0, "Robust filter should reject the spike rather than smear it in")
+
+	plain, err := NewFilter(9, 0, 2)
+	assert.NoError(t, err, "No filter initialization error expected")
+	plainSmoothed, err := plain.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+	assert.Greater(t, plainSmoothed[n/2], smoothed[n/2], "Non-robust filter should be dragged further by the spike")
+}
+
+func Test_RobustFilter_DefaultsApplied(t *testing.T) {
+	filter, err := NewRobustFilter(7, 0, 2, RobustOptions{})
+	assert.NoError(t, err, "No filter initialization error expected")
+	rf := filter.(robustFilter)
+	assert.Equal(t, 10, rf.options.MaxIter)
+	assert.Equal(t, 1e-6, rf.options.Tolerance)
+	assert.Equal(t, 4.685, rf.options.TuningConstant)
+}
@@ -11,6 +11,17 @@ import (
 // errors occur only if the filter window is larger than the data
 type Filter interface {
 	Process(data []float64, x []float64) ([]float64, error)
+	// Coefficients returns the convolution weight matrix used internally by
+	// Process, one row per window position it is applied to, so callers can
+	// inspect or export the filter kernel for use elsewhere. Filters that
+	// fit each window separately (NewFilterUnequal, NewRobustFilter) have
+	// no fixed kernel and return nil.
+	Coefficients() [][]float64
+	// ProcessDerivative returns the order-th derivative of the fit this
+	// filter would otherwise produce, scaled by order!/h^order, without
+	// needing to construct a separate filter for every derivative order of
+	// interest.
+	ProcessDerivative(data []float64, x []float64, order int) ([]float64, error)
 }
 
 // filterConfiguration provides configurations for the filter
@@ -23,20 +34,40 @@ type filterConfiguration struct {
 	polynomial int
 
 	weights [][]float64
+
+	// unequal opts the filter into the per-point weighted least-squares
+	// path used for non-uniformly spaced x values, see NewFilterUnequal.
+	unequal bool
+	// cache memoizes the local weight rows computed by processUnequal,
+	// keyed by the shape of the x offsets within a window, since the same
+	// stride pattern often recurs across a data set.
+	cache map[string][]float64
 }
 
-// NewFilter creates new savitzky golay based on the provided attributes
-func NewFilter(windowSize int, derivitive int, polynomial int) (Filter, error) {
-	options := filterConfiguration{windowSize: windowSize, derivative: derivitive, polynomial: polynomial}
-	if options.windowSize%2 == 0 || options.windowSize < 5 {
-		return nil, fmt.Errorf("options.WindowSize [%d] must be odd and equal to or greater than 5", options.windowSize)
+// validateFilterOptions checks the constraints shared by every constructor
+// in this package.
+func validateFilterOptions(windowSize int, derivative int, polynomial int) error {
+	if windowSize%2 == 0 || windowSize < 5 {
+		return fmt.Errorf("options.WindowSize [%d] must be odd and equal to or greater than 5", windowSize)
 	}
-	if options.derivative < 0 {
-		return nil, fmt.Errorf("options.Derivative [%d] must be euqal or greater than 0", options.derivative)
+	if derivative < 0 {
+		return fmt.Errorf("options.Derivative [%d] must be euqal or greater than 0", derivative)
 	}
-	if options.polynomial < 0 {
-		return nil, fmt.Errorf("options.Polynomial [%d] must be equal or greater than 0", options.polynomial)
+	if polynomial < 0 {
+		return fmt.Errorf("options.Polynomial [%d] must be equal or greater than 0", polynomial)
+	}
+	if polynomial >= windowSize {
+		return fmt.Errorf("options.Polynomial [%d] must be less than options.WindowSize [%d]", polynomial, windowSize)
+	}
+	return nil
+}
+
+// NewFilter creates new savitzky golay based on the provided attributes
+func NewFilter(windowSize int, derivitive int, polynomial int) (Filter, error) {
+	if err := validateFilterOptions(windowSize, derivitive, polynomial); err != nil {
+		return nil, err
 	}
+	options := filterConfiguration{windowSize: windowSize, derivative: derivitive, polynomial: polynomial}
 	options.weights = options.computeWeights()
 	return options, nil
 }
@@ -53,6 +84,18 @@ func (options filterConfiguration) Process(data []float64, h []float64) ([]float
 		return nil, fmt.Errorf("data length [%d] must be larger than options.WindowSize[%d]", len(data), options.windowSize)
 	}
 
+	if options.unequal {
+		return options.processUnequal(data, h)
+	}
+	if !isUniform(h) {
+		// The uniform weights don't apply to this x spacing; fall back to a
+		// per-point fit but don't persist a cache on an instance that was
+		// never opted into the unequal path.
+		fallback := options
+		fallback.cache = make(map[string][]float64)
+		return fallback.processUnequal(data, h)
+	}
+
 	halfWindow := int(math.Floor(float64(options.windowSize) / 2.0))
 	numPoints := len(data)
 	results := make([]float64, numPoints)
@@ -100,53 +143,9 @@ func getHs(h []float64, center int, half int, derivative int) float64 {
 	return math.Pow(hs/float64(count), float64(derivative))
 }
 
-func gramPolynomial(i int, m int, k int, s int) float64 {
-	result := 0.0
-	if k > 0 {
-		result =
-			float64(float64(4*k-2)/float64(k*(2*m-k+1)))*
-				(float64(i)*gramPolynomial(i, m, k-1, s)+float64(s)*gramPolynomial(i, m, k-1, s-1)) -
-				(float64((k-1)*(2*m+k))/float64(k*(2*m-k+1)))*
-					gramPolynomial(i, m, k-2, s)
-	} else {
-		if k == 0 && s == 0 {
-			result = 1
-		} else {
-			result = 0
-		}
-	}
-	return result
-}
-
-func productOfRange(a, b int) int {
-	gf := 1
-	if a >= b {
-		for j := a - b + 1; j <= a; j++ {
-			gf *= j
-		}
-	}
-	return gf
-}
-
-func polyWeight(i, t, windowMiddle, polynomial, derivitive int) float64 {
-	sum := 0.0
-	for k := 0; k <= polynomial; k++ {
-		sum +=
-			float64(2*k+1) *
-				(float64(productOfRange(2*windowMiddle, k)) / float64(productOfRange(2*windowMiddle+k+1, k+1))) *
-				gramPolynomial(i, windowMiddle, k, 0) * gramPolynomial(t, windowMiddle, k, derivitive)
-	}
-	return sum
-}
-
-func (options *filterConfiguration) computeWeights() [][]float64 {
-	weights := make([][]float64, options.windowSize)
-	windowMiddle := int(math.Floor(float64(options.windowSize) / 2.0))
-	for row := -windowMiddle; row <= windowMiddle; row++ {
-		weights[row+windowMiddle] = make([]float64, options.windowSize)
-		for col := -windowMiddle; col <= windowMiddle; col++ {
-			weights[row+windowMiddle][col+windowMiddle] = polyWeight(col, row, windowMiddle, options.polynomial, options.derivative)
-		}
-	}
-	return weights
+// Coefficients returns the convolution weight matrix computed for this
+// filter, or nil for a filter created by NewFilterUnequal. See
+// Filter.Coefficients.
+func (options filterConfiguration) Coefficients() [][]float64 {
+	return options.weights
 }
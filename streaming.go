@@ -0,0 +1,132 @@
+package savitzkygolay
+
+// StreamingFilter applies a Savitzky-Golay filter to samples presented one
+// at a time instead of as a fixed slice, for use in real-time smoothing
+// pipelines where the full data set is never available up front (for
+// example a per-connection loop that consumes a stream of heartbeats or
+// sensor readings). It holds the last windowSize samples and reuses the
+// same precomputed weights as Filter.Process, so a call to Push costs
+// amortized O(windowSize) regardless of how many samples have been seen.
+//
+// The leading halfWindow results of a batch Process call all come from the
+// very first window, so Push cannot hand them out any sooner than it hands
+// out the first centered result; it queues them ahead of it and then
+// returns one queued result per call. The queue therefore always holds
+// halfWindow results, so every value Push returns belongs to the sample
+// windowSize-1 positions before the one just pushed. Flush then returns
+// the halfWindow queued results followed by the trailing border results,
+// so that the concatenation of every Push result with Flush's result
+// reconstructs Process exactly.
+type StreamingFilter struct {
+	options    filterConfiguration
+	halfWindow int
+	window     []float64
+	xwindow    []float64
+	filled     bool
+	pending    []float64
+}
+
+// NewStreamingFilter creates a StreamingFilter. windowSize, derivative and
+// polynomial have the same meaning and constraints as in NewFilter.
+func NewStreamingFilter(windowSize int, derivative int, polynomial int) (*StreamingFilter, error) {
+	f, err := NewFilter(windowSize, derivative, polynomial)
+	if err != nil {
+		return nil, err
+	}
+	return &StreamingFilter{
+		options:    f.(filterConfiguration),
+		halfWindow: windowSize / 2,
+	}, nil
+}
+
+// Push feeds a single (x, y) sample into the filter and, once windowSize
+// samples have accumulated, returns a smoothed value along with
+// ok == true; before that it returns ok == false since there is not yet
+// enough data to fit a window. Once it starts returning values, Push
+// returns exactly one value per call, in the same order Process would
+// produce them, each lagging windowSize-1 samples behind the latest input
+// because of the queue described above.
+func (s *StreamingFilter) Push(x, y float64) (smoothed float64, ok bool) {
+	s.window = append(s.window, y)
+	s.xwindow = append(s.xwindow, x)
+	if len(s.window) > s.options.windowSize {
+		s.window = s.window[1:]
+		s.xwindow = s.xwindow[1:]
+	}
+	if len(s.window) < s.options.windowSize {
+		return 0, false
+	}
+
+	if !s.filled {
+		s.filled = true
+		s.pending = append(s.pending, s.leadingResults()...)
+	}
+	s.pending = append(s.pending, s.centeredResult())
+
+	smoothed, s.pending = s.pending[0], s.pending[1:]
+	return smoothed, true
+}
+
+// leadingResults computes the halfWindow border results produced by the
+// very first full window, mirroring the leading border loop in
+// Process (sg.go).
+func (s *StreamingFilter) leadingResults() []float64 {
+	results := make([]float64, s.halfWindow)
+	for i := 0; i < s.halfWindow; i++ {
+		wg := s.options.weights[i]
+		d := 0.0
+		for l := 0; l < s.options.windowSize; l++ {
+			d += wg[l] * s.window[l]
+		}
+		hs := getHs(s.xwindow, i, s.halfWindow, s.options.derivative)
+		results[i] = d / hs
+	}
+	return results
+}
+
+// centeredResult applies the centered weight row to the current window,
+// the same computation the interior loop in Process uses.
+func (s *StreamingFilter) centeredResult() float64 {
+	wg := s.options.weights[s.halfWindow]
+	d := 0.0
+	for l := 0; l < s.options.windowSize; l++ {
+		d += wg[l] * s.window[l]
+	}
+	hs := getHs(s.xwindow, s.halfWindow, s.halfWindow, s.options.derivative)
+	return d / hs
+}
+
+// Flush drains whatever Push has not yet returned: first the halfWindow
+// results still held in the queue described above, then the
+// trailing border results computed from the final window, applying the
+// same asymmetric border weight rows that Process uses at the end of a
+// batch. Call it once no more samples will arrive. The filter should be
+// Reset before it is reused.
+func (s *StreamingFilter) Flush() []float64 {
+	if !s.filled {
+		return nil
+	}
+
+	results := append([]float64(nil), s.pending...)
+	s.pending = nil
+
+	for i := 0; i < s.halfWindow; i++ {
+		wg := s.options.weights[s.halfWindow+i+1]
+		d := 0.0
+		for l := 0; l < s.options.windowSize; l++ {
+			d += wg[l] * s.window[l]
+		}
+		hs := getHs(s.xwindow, s.halfWindow+i+1, s.halfWindow, s.options.derivative)
+		results = append(results, d/hs)
+	}
+	return results
+}
+
+// Reset clears the accumulated window so the filter can start a new stream
+// without recomputing its weight matrix.
+func (s *StreamingFilter) Reset() {
+	s.window = s.window[:0]
+	s.xwindow = s.xwindow[:0]
+	s.pending = nil
+	s.filled = false
+}
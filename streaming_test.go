@@ -0,0 +1,62 @@
+package savitzkygolay
+
+import (
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_StreamingFilter_Args(t *testing.T) {
+	_, err := NewStreamingFilter(6, 0, 3)
+	assert.Error(t, err, "Window size even")
+
+	_, err = NewStreamingFilter(7, -1, 3)
+	assert.Error(t, err, "Derivitive must be non-negative")
+}
+
+func Test_StreamingFilter_MatchesProcess(t *testing.T) {
+	const windowSize = 7
+	const polynomial = 3
+
+	xs := make([]float64, testSize)
+	ys := make([]float64, testSize)
+	for i := range xs {
+		xs[i] = float64(i)
+		ys[i] = 20 * math.Sin(float64(i)/math.Pi/6)
+	}
+
+	batch, err := NewFilter(windowSize, 0, polynomial)
+	assert.NoError(t, err, "No filter initialization error expected")
+	expected, err := batch.Process(ys, xs)
+	assert.NoError(t, err, "No error expected")
+
+	streaming, err := NewStreamingFilter(windowSize, 0, polynomial)
+	assert.NoError(t, err, "No streaming filter initialization error expected")
+
+	var got []float64
+	for i := range xs {
+		smoothed, ok := streaming.Push(xs[i], ys[i])
+		if ok {
+			got = append(got, smoothed)
+		}
+	}
+	got = append(got, streaming.Flush()...)
+
+	assert.Equal(t, len(expected), len(got), "Streaming filter should produce one result per input sample")
+	for i := range expected {
+		assert.InDelta(t, expected[i], got[i], 1e-9, "Streaming filter should match batch Process at index %d", i)
+	}
+}
+
+func Test_StreamingFilter_Reset(t *testing.T) {
+	streaming, err := NewStreamingFilter(5, 0, 3)
+	assert.NoError(t, err, "No streaming filter initialization error expected")
+	for i := 0; i < 4; i++ {
+		_, ok := streaming.Push(float64(i), float64(i))
+		assert.False(t, ok, "Not enough samples yet")
+	}
+	streaming.Reset()
+	_, ok := streaming.Push(0, 0)
+	assert.False(t, ok, "Reset should clear the accumulated window")
+}
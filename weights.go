@@ -0,0 +1,77 @@
+package savitzkygolay
+
+import (
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// computeWeights builds the convolution weight matrix used by Process.
+//
+// It fits a degree-`polynomial` polynomial through a window of windowSize
+// equally spaced points by least squares: the Vandermonde matrix
+// A[j][k] = (j-windowMiddle)^k is formed once for j in [0, windowSize) and
+// k in [0, polynomial], and the normal equations (A^T A) C = A^T are solved
+// via a matrix inverse to give the coefficient matrix C. Differentiating
+// the fitted polynomial `derivative` times and evaluating it at an offset t
+// from the window center yields the weight row for that offset; t ranges
+// over the whole window because Process also needs the asymmetric rows it
+// uses for the points near the start and end of a data set, where the
+// point being estimated is not centered in the window.
+func (options *filterConfiguration) computeWeights() [][]float64 {
+	windowMiddle := options.windowSize / 2
+	order := options.polynomial + 1
+
+	a := mat.NewDense(options.windowSize, order, nil)
+	for j := 0; j < options.windowSize; j++ {
+		x := float64(j - windowMiddle)
+		p := 1.0
+		for k := 0; k < order; k++ {
+			a.Set(j, k, p)
+			p *= x
+		}
+	}
+
+	var ata mat.Dense
+	ata.Mul(a.T(), a)
+	var ataInv mat.Dense
+	if err := ataInv.Inverse(&ata); err != nil {
+		panic(fmt.Sprintf("savitzkygolay: could not solve normal equations for windowSize %d, polynomial %d: %v", options.windowSize, options.polynomial, err))
+	}
+	var coefficients mat.Dense // (polynomial+1) x windowSize
+	coefficients.Mul(&ataInv, a.T())
+
+	weights := make([][]float64, options.windowSize)
+	for row := -windowMiddle; row <= windowMiddle; row++ {
+		t := float64(row)
+		w := make([]float64, options.windowSize)
+		for j := 0; j < options.windowSize; j++ {
+			sum := 0.0
+			for k := options.derivative; k < order; k++ {
+				sum += coefficients.At(k, j) * fallingFactorial(k, options.derivative) * intPow(t, k-options.derivative)
+			}
+			w[j] = sum
+		}
+		weights[row+windowMiddle] = w
+	}
+	return weights
+}
+
+// fallingFactorial returns k!/(k-d)!, the coefficient picked up by the d-th
+// derivative of x^k.
+func fallingFactorial(k, d int) float64 {
+	r := 1.0
+	for i := k - d + 1; i <= k; i++ {
+		r *= float64(i)
+	}
+	return r
+}
+
+// intPow returns x^n for a non-negative integer n, treating 0^0 as 1.
+func intPow(x float64, n int) float64 {
+	r := 1.0
+	for i := 0; i < n; i++ {
+		r *= x
+	}
+	return r
+}
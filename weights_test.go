@@ -0,0 +1,30 @@
+package savitzkygolay
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/assert"
+)
+
+func Test_ComputeWeights_Coefficients(t *testing.T) {
+	f, err := NewFilter(7, 0, 3)
+	assert.NoError(t, err, "No filter initialization error expected")
+
+	coeffs := f.Coefficients()
+	assert.Equal(t, 7, len(coeffs), "One weight row per window position")
+	for _, row := range coeffs {
+		assert.Equal(t, 7, len(row), "Each weight row spans the window")
+	}
+
+	// The centered row must reproduce a constant exactly: its weights sum to 1.
+	sum := 0.0
+	for _, w := range coeffs[3] {
+		sum += w
+	}
+	assert.InDelta(t, 1.0, sum, 1e-9, "Centered smoothing weights should sum to 1")
+}
+
+func Test_ComputeWeights_RejectsPolynomialTooLarge(t *testing.T) {
+	_, err := NewFilter(5, 0, 5)
+	assert.Error(t, err, "Polynomial must be less than window size")
+}